@@ -0,0 +1,406 @@
+// Package core holds the blockchain's core data structures and rules:
+// blocks, the chain itself, transaction/UTXO validation, Merkle rooting and
+// proof-of-work. It has no knowledge of networking or RPC -- p2p and rpc
+// both import core and drive it through this package's exported API.
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/merkle"
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/storage"
+)
+
+// -----------------------Blockchain Data Structure:-----------------
+// Block represents a block in the blockchain.
+type Block struct {
+	Transaction  []Transaction
+	Nonce        int
+	PreviousHash string
+	CurrentHash  string
+	MerkleRoot   string
+	// LeafHashes holds the hashed (sha256(sha256(tx))) leaves of this
+	// block's Merkle tree, in transaction order, so GetMerkleProof can
+	// rebuild the tree without re-hashing the raw transactions.
+	LeafHashes [][32]byte
+	Timestamp  int64
+	// Difficulty is the compact ("nBits") encoding of the 256-bit PoW
+	// target this block's CurrentHash had to meet. See difficulty.go.
+	Difficulty uint32
+}
+
+// Broadcaster is notified whenever NewBlock mines a new block, so a p2p
+// node can gossip it to peers without core importing the p2p package.
+type Broadcaster interface {
+	BroadcastBlock(block Block)
+}
+
+// Blockchain represents the blockchain structure.
+//
+// A *Blockchain is shared across goroutines once it's handed to a p2p.Node
+// (each peer connection runs on its own goroutine and calls AcceptBlock /
+// GetBlockByHash concurrently), so every exported method that reads or
+// writes its fields takes mu. Unexported helpers that assume mu is already
+// held are used internally to avoid relocking within a single call.
+type Blockchain struct {
+	mu sync.RWMutex
+
+	Blocks                  []Block
+	NumTransactionsPerBlock int
+	BlockHashMin            string
+	BlockHashMax            string
+
+	// Store persists every block this Blockchain creates or loads, so the
+	// chain survives restart. See NewBlockchain and LoadFromStore.
+	Store storage.Store
+	// HeaderHashList caches the hash of every block in order, and hashIndex
+	// maps each hash back to its height, giving GetBlockByHash O(1) lookups
+	// instead of a scan over Blocks.
+	HeaderHashList []string
+	hashIndex      map[string]int
+
+	// UTXOSet tracks unspent outputs across every confirmed transaction, so
+	// NewBlock can reject double-spends and unbalanced transactions.
+	UTXOSet *UTXOSet
+
+	// Broadcaster, if set, is told about every block NewBlock mines. Blocks
+	// adopted through AcceptBlock are not passed to it.
+	Broadcaster Broadcaster
+	// sideBlocks holds blocks that don't extend the current tip, keyed by
+	// hash, in case the chain they root later out-paces the main one. See
+	// AcceptBlock and the longest-chain reorg it performs.
+	sideBlocks map[string]Block
+}
+
+// ------------------------Transaction Management:-----------------
+// recentBlock returns the most recent block in the blockchain. Callers must
+// hold bc.mu.
+func (bc *Blockchain) recentBlock() Block {
+	if len(bc.Blocks) == 0 {
+		return Block{}
+	}
+	return bc.Blocks[len(bc.Blocks)-1]
+}
+
+// GetRecentBlock returns the most recent block in the blockchain.
+func (bc *Blockchain) GetRecentBlock() Block {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.recentBlock()
+}
+
+// NewBlock verifies each of transactions against the UTXO set, then (if
+// enough of them are valid) mines and appends a new block from the ones
+// that pass, persisting it and notifying Broadcaster.
+func (bc *Blockchain) NewBlock(transactions []Transaction) {
+	if len(transactions) == 0 {
+		fmt.Println("No Transactions to be added to Block.")
+		return
+	}
+
+	bc.mu.Lock()
+
+	if bc.UTXOSet == nil {
+		bc.UTXOSet = NewUTXOSet()
+	}
+
+	// Verify against a scratch copy of the UTXO set, applying each accepted
+	// transaction to it as we go, so a later transaction in this same batch
+	// that spends an output an earlier one already claimed is caught here
+	// instead of both landing in the block -- bc.UTXOSet itself isn't
+	// updated until appendBlock.
+	scratch := bc.UTXOSet.Clone()
+	validTransactions := make([]Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		if err := scratch.VerifyTransaction(&tx); err != nil {
+			fmt.Println("Rejecting invalid transaction:", err)
+			continue
+		}
+		scratch.Apply(&tx)
+		validTransactions = append(validTransactions, tx)
+	}
+
+	if len(validTransactions) < bc.NumTransactionsPerBlock {
+		fmt.Println("Transactions to create a Block not reached yet.")
+		bc.mu.Unlock()
+		return
+	}
+
+	previousBlock := bc.recentBlock()
+	previousHash := previousBlock.CurrentHash
+	merkleRoot, leafHashes := computeMerkle(validTransactions)
+	timestamp := time.Now().Unix()
+	bits := bc.nextDifficultyBits()
+	nonce, currentHash := FindValidNonce(validTransactions, timestamp, previousHash, merkleRoot, bits)
+
+	block := Block{
+		Transaction:  validTransactions,
+		Nonce:        nonce,
+		PreviousHash: previousHash,
+		CurrentHash:  currentHash,
+		MerkleRoot:   merkleRoot,
+		LeafHashes:   leafHashes,
+		Timestamp:    timestamp,
+		Difficulty:   bits,
+	}
+
+	err := bc.appendBlock(block)
+	bc.mu.Unlock()
+	if err != nil {
+		fmt.Println("Failed to persist block:", err)
+		return
+	}
+
+	if bc.Broadcaster != nil {
+		bc.Broadcaster.BroadcastBlock(block)
+	}
+}
+
+// appendBlock adds block to the tip, updates the UTXO set and indexes, and
+// persists it if a Store is configured. Callers are expected to have
+// already validated block and to hold bc.mu.
+func (bc *Blockchain) appendBlock(block Block) error {
+	if bc.UTXOSet == nil {
+		bc.UTXOSet = NewUTXOSet()
+	}
+
+	height := len(bc.Blocks)
+	bc.Blocks = append(bc.Blocks, block)
+	bc.indexBlock(block, height)
+	for _, tx := range block.Transaction {
+		bc.UTXOSet.Apply(&tx)
+	}
+
+	if bc.Store == nil {
+		return nil
+	}
+	return bc.persist(block, height)
+}
+
+// ---------------------------Merkle Tree Implementation:----------------------
+// computeMerkle builds a merkle.MerkleTree over transactions and returns its
+// hex-encoded root alongside the tree's leaf hashes, ready to be stored on
+// the Block so a later GetMerkleProof call doesn't need to re-hash them.
+func computeMerkle(transactions []Transaction) (string, [][32]byte) {
+	leaves := make([][]byte, len(transactions))
+	for i, tx := range transactions {
+		leaves[i] = tx.Bytes()
+	}
+	tree := merkle.New(leaves)
+	root := tree.Root()
+	return hex.EncodeToString(root[:]), tree.Leaves()
+}
+
+// GetMerkleProof returns the hex-encoded Merkle root of the block at
+// blockIdx and an SPV-style inclusion proof for the transaction at txIdx
+// within it, suitable for merkle.VerifyProof.
+func (bc *Blockchain) GetMerkleProof(blockIdx, txIdx int) (string, []merkle.ProofNode, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if blockIdx < 0 || blockIdx >= len(bc.Blocks) {
+		return "", nil, fmt.Errorf("block index %d out of range", blockIdx)
+	}
+	block := bc.Blocks[blockIdx]
+	if txIdx < 0 || txIdx >= len(block.LeafHashes) {
+		return "", nil, fmt.Errorf("transaction index %d out of range", txIdx)
+	}
+
+	tree := merkle.NewFromLeafHashes(block.LeafHashes)
+	proof, err := tree.Path(block.LeafHashes[txIdx])
+	if err != nil {
+		return "", nil, err
+	}
+	root := tree.Root()
+	return hex.EncodeToString(root[:]), proof, nil
+}
+
+// CreateHash generates a block header hash from its constituent fields.
+func CreateHash(transactions []Transaction, nonce int, previousHash, merkleRoot string, timestamp int64, bits uint32) string {
+	prefix := headerPrefix(transactions, previousHash, merkleRoot, timestamp, bits)
+	return hashHeader(prefix, nonce)
+}
+
+// headerPrefix serializes every header field except the nonce, so
+// FindValidNonce can build it once and reuse it across every nonce it
+// tries instead of re-serializing the transactions (via reflection-based
+// fmt.Sprintf, in the old implementation) on every attempt.
+func headerPrefix(transactions []Transaction, previousHash, merkleRoot string, timestamp int64, bits uint32) []byte {
+	var buf bytes.Buffer
+	for _, tx := range transactions {
+		buf.Write(tx.Bytes())
+	}
+	buf.WriteString(previousHash)
+	buf.WriteString(merkleRoot)
+	binary.Write(&buf, binary.BigEndian, timestamp)
+	binary.Write(&buf, binary.BigEndian, bits)
+	return buf.Bytes()
+}
+
+// hashHeader appends nonce to prefix and returns the hex-encoded sha256 of
+// the result.
+func hashHeader(prefix []byte, nonce int) string {
+	data := make([]byte, len(prefix)+8)
+	copy(data, prefix)
+	binary.BigEndian.PutUint64(data[len(prefix):], uint64(nonce))
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// ---------------------------Proof Of work (POW) Consensus-------------------------
+// FindValidNonce searches for a nonce such that the resulting header hash,
+// read as a big-endian integer, is at or below the target bits encodes.
+func FindValidNonce(transactions []Transaction, timestamp int64, previousHash, merkleRoot string, bits uint32) (int, string) {
+	target := CompactToBig(bits)
+	prefix := headerPrefix(transactions, previousHash, merkleRoot, timestamp, bits)
+	for nonce := 0; ; nonce++ {
+		hash := hashHeader(prefix, nonce)
+		if HashToBig(hash).Cmp(target) <= 0 {
+			return nonce, hash
+		}
+	}
+}
+
+// DisplayBlocks prints the information of all blocks in the blockchain.
+func (bc *Blockchain) DisplayBlocks() {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	for i, block := range bc.Blocks {
+		fmt.Printf("\nBlock %d:\n", i)
+		fmt.Printf("Transaction: %v\n", block.Transaction)
+		fmt.Printf("Nonce: %d\n", block.Nonce)
+		fmt.Printf("Previous Hash: %s\n", block.PreviousHash)
+		fmt.Printf("Current Hash: %s\n", block.CurrentHash)
+		fmt.Printf("Merkle Root: %s\n\n", block.MerkleRoot)
+	}
+}
+
+// ChangeBlock modifies a transaction in a block at the specified index.
+func (bc *Blockchain) ChangeBlock(index int, newTransaction Transaction) bool {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if index >= 0 && index < len(bc.Blocks) {
+		oldBlock := &bc.Blocks[index]
+		transactions := append([]Transaction{}, oldBlock.Transaction...)
+		transactions = append(transactions, newTransaction)
+
+		merkleRoot, leafHashes := computeMerkle(transactions)
+		previousHash := ""
+		if index > 0 {
+			previousHash = bc.Blocks[index-1].CurrentHash
+		}
+
+		timestamp := time.Now().Unix()
+		bits := oldBlock.Difficulty
+		nonce, currentHash := FindValidNonce(transactions, timestamp, previousHash, merkleRoot, bits)
+
+		newBlock := &Block{
+			Transaction:  transactions,
+			Nonce:        nonce,
+			PreviousHash: previousHash,
+			CurrentHash:  currentHash,
+			MerkleRoot:   merkleRoot,
+			LeafHashes:   leafHashes,
+			Timestamp:    timestamp,
+			Difficulty:   bits,
+		}
+
+		bc.Blocks[index] = *newBlock
+		return true
+	}
+	return false
+}
+
+// -----------------------------Block Validation and Consistency:-------------------------
+// VerifyChain checks every block in the chain: that it links to the
+// previous block's hash, that its MerkleRoot and CurrentHash are what its
+// own fields recompute to, and that CurrentHash actually meets the PoW
+// target its Difficulty encodes. A tampered block that only patches its own
+// fields, without redoing the work for itself and every block after it,
+// fails one of these checks.
+func (bc *Blockchain) VerifyChain() bool {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	for i, block := range bc.Blocks {
+		previousHash := ""
+		if i > 0 {
+			previousHash = bc.Blocks[i-1].CurrentHash
+		}
+		if err := bc.validateBlock(block, i); err != nil {
+			return false
+		}
+		if block.PreviousHash != previousHash {
+			return false
+		}
+	}
+	return true
+}
+
+// validateBlock checks block in isolation: that its MerkleRoot and
+// CurrentHash are what its own fields recompute to, that CurrentHash meets
+// the PoW target Difficulty encodes, and (unless it's a genesis block) that
+// its PreviousHash refers to a block this Blockchain already knows about.
+// It does not check that block extends the current tip; AcceptBlock uses
+// that distinction to decide between appending and stashing a side chain.
+// height is the position block occupies (or would occupy, if it extends the
+// current tip) in the chain being validated -- only height 0 may have an
+// empty PreviousHash, regardless of how many blocks bc.Blocks already holds.
+// Callers must hold bc.mu.
+func (bc *Blockchain) validateBlock(block Block, height int) error {
+	merkleRoot, _ := computeMerkle(block.Transaction)
+	if merkleRoot != block.MerkleRoot {
+		return fmt.Errorf("block %s: merkle root does not match its transactions", block.CurrentHash)
+	}
+
+	recomputedHash := CreateHash(block.Transaction, block.Nonce, block.PreviousHash, block.MerkleRoot, block.Timestamp, block.Difficulty)
+	if recomputedHash != block.CurrentHash {
+		return fmt.Errorf("block %s: hash does not match its header fields", block.CurrentHash)
+	}
+
+	if HashToBig(block.CurrentHash).Cmp(CompactToBig(block.Difficulty)) > 0 {
+		return fmt.Errorf("block %s: does not meet its proof-of-work target", block.CurrentHash)
+	}
+
+	if block.PreviousHash == "" {
+		if height != 0 {
+			return fmt.Errorf("block %s: empty previous hash at height %d, only the genesis block may have one", block.CurrentHash, height)
+		}
+		return nil
+	}
+	if _, ok := bc.blockByHash(block.PreviousHash); !ok {
+		if _, ok := bc.sideBlocks[block.PreviousHash]; !ok {
+			return fmt.Errorf("block %s: previous hash %s is unknown", block.CurrentHash, block.PreviousHash)
+		}
+	}
+	return nil
+}
+
+// SetNumberOfTransactionsPerBlock sets the number of transactions per block.
+func (bc *Blockchain) SetNumberOfTransactionsPerBlock(numTransactions int) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if numTransactions >= 1 {
+		bc.NumTransactionsPerBlock = numTransactions
+	} else {
+		fmt.Println("No. of transactions per block at least be 1")
+	}
+}
+
+// SetBlockHashRangeForBlockCreation sets the range of block hash values for block creation.
+func (bc *Blockchain) SetBlockHashRangeForBlockCreation(min, max string) {
+	// You can add input validation here to ensure min and max are valid hash values.
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.BlockHashMin = min
+	bc.BlockHashMax = max
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/storage"
+)
+
+// Config holds the runtime parameters a Blockchain is constructed with.
+type Config struct {
+	NumTransactionsPerBlock int
+	BlockHashMin            string
+	BlockHashMax            string
+}
+
+const (
+	keyPrefixHeight byte = 'h' // height -> hash
+	keyPrefixBlock  byte = 'b' // hash -> block
+)
+
+// heightKey builds the storage key that maps a block height to its hash.
+func heightKey(height int) []byte {
+	buf := make([]byte, 5)
+	buf[0] = keyPrefixHeight
+	binary.BigEndian.PutUint32(buf[1:], uint32(height))
+	return buf
+}
+
+// blockKey builds the storage key that maps a block hash to its contents.
+func blockKey(hash string) []byte {
+	return append([]byte{keyPrefixBlock}, []byte(hash)...)
+}
+
+// NewBlockchain creates a Blockchain backed by store and loads any blocks
+// already persisted there, so a restarted node picks up where it left off.
+func NewBlockchain(store storage.Store, cfg Config) (*Blockchain, error) {
+	bc := &Blockchain{
+		Store:                   store,
+		NumTransactionsPerBlock: cfg.NumTransactionsPerBlock,
+		BlockHashMin:            cfg.BlockHashMin,
+		BlockHashMax:            cfg.BlockHashMax,
+	}
+	if err := bc.LoadFromStore(); err != nil {
+		return nil, err
+	}
+	return bc, nil
+}
+
+// LoadFromStore replays every block persisted under the height->hash index
+// back into memory, rebuilding Blocks and HeaderHashList in order.
+func (bc *Blockchain) LoadFromStore() error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.Blocks = nil
+	bc.HeaderHashList = nil
+	bc.hashIndex = nil
+	bc.UTXOSet = NewUTXOSet()
+
+	for height := 0; ; height++ {
+		hashBytes, err := bc.Store.Get(heightKey(height))
+		if err != nil {
+			if err == storage.ErrKeyNotFound {
+				break
+			}
+			return err
+		}
+
+		raw, err := bc.Store.Get(blockKey(string(hashBytes)))
+		if err != nil {
+			return fmt.Errorf("load block %d: %w", height, err)
+		}
+
+		var block Block
+		if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&block); err != nil {
+			return fmt.Errorf("decode block %d: %w", height, err)
+		}
+
+		bc.Blocks = append(bc.Blocks, block)
+		bc.indexBlock(block, height)
+		for _, tx := range block.Transaction {
+			bc.UTXOSet.Apply(&tx)
+		}
+	}
+	return nil
+}
+
+// persist writes block to the store under both its height and hash keys.
+func (bc *Blockchain) persist(block Block, height int) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(block); err != nil {
+		return fmt.Errorf("encode block %d: %w", height, err)
+	}
+	if err := bc.Store.Put(blockKey(block.CurrentHash), buf.Bytes()); err != nil {
+		return err
+	}
+	return bc.Store.Put(heightKey(height), []byte(block.CurrentHash))
+}
+
+// indexBlock records block in the in-memory HeaderHashList/hashIndex so
+// GetBlockByHash is O(1) regardless of chain length.
+func (bc *Blockchain) indexBlock(block Block, height int) {
+	if bc.hashIndex == nil {
+		bc.hashIndex = make(map[string]int)
+	}
+	bc.HeaderHashList = append(bc.HeaderHashList, block.CurrentHash)
+	bc.hashIndex[block.CurrentHash] = height
+}
+
+// blockByHash returns the block with the given hash, looked up through the
+// in-memory hashIndex rather than scanning bc.Blocks. Callers must hold
+// bc.mu.
+func (bc *Blockchain) blockByHash(hash string) (Block, bool) {
+	height, ok := bc.hashIndex[hash]
+	if !ok {
+		return Block{}, false
+	}
+	return bc.Blocks[height], true
+}
+
+// GetBlockByHash returns the block with the given hash.
+func (bc *Blockchain) GetBlockByHash(hash string) (Block, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.blockByHash(hash)
+}
+
+// Height returns the number of blocks currently in the chain.
+func (bc *Blockchain) Height() int {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return len(bc.Blocks)
+}
+
+// BlockAt returns the block at the given height.
+func (bc *Blockchain) BlockAt(height int) (Block, bool) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	if height < 0 || height >= len(bc.Blocks) {
+		return Block{}, false
+	}
+	return bc.Blocks[height], true
+}
@@ -0,0 +1,57 @@
+package core
+
+import "testing"
+
+// TestVerifyChain_SingleBlock guards against validateBlock's genesis check
+// comparing against len(bc.Blocks) (the live chain being validated) instead
+// of the height of the block under test, which made VerifyChain reject
+// every chain that had ever mined a block.
+func TestVerifyChain_SingleBlock(t *testing.T) {
+	bc := &Blockchain{NumTransactionsPerBlock: 1}
+	tx := Transaction{Outputs: []TxOutput{{PubKeyHash: "alice", Amount: 10}}}
+
+	bc.NewBlock([]Transaction{tx})
+	if len(bc.Blocks) != 1 {
+		t.Fatalf("len(bc.Blocks) = %d, want 1", len(bc.Blocks))
+	}
+
+	if !bc.VerifyChain() {
+		t.Fatal("VerifyChain() = false for a single valid block, want true")
+	}
+}
+
+// TestNewBlock_RejectsDoubleSpendWithinBatch guards against NewBlock
+// verifying each candidate transaction only against the committed UTXO
+// set, which let two transactions in the same batch spend the same output
+// and both be mined into the same block.
+func TestNewBlock_RejectsDoubleSpendWithinBatch(t *testing.T) {
+	bc := &Blockchain{NumTransactionsPerBlock: 1}
+
+	wallet, err := NewWallet()
+	if err != nil {
+		t.Fatalf("NewWallet() error: %v", err)
+	}
+	funding := Transaction{Outputs: []TxOutput{{PubKeyHash: wallet.Address(), Amount: 100}}}
+	bc.UTXOSet = NewUTXOSet()
+	bc.UTXOSet.Apply(&funding)
+
+	spendSameInput := func(recipient string) Transaction {
+		tx := Transaction{
+			Inputs:  []TxInput{{TxHash: funding.Hash(), OutputIndex: 0}},
+			Outputs: []TxOutput{{PubKeyHash: recipient, Amount: 100}},
+		}
+		if err := wallet.SignTransaction(&tx); err != nil {
+			t.Fatalf("SignTransaction() error: %v", err)
+		}
+		return tx
+	}
+
+	bc.NewBlock([]Transaction{spendSameInput("bob"), spendSameInput("carol")})
+
+	if len(bc.Blocks) != 1 {
+		t.Fatalf("len(bc.Blocks) = %d, want 1", len(bc.Blocks))
+	}
+	if got := len(bc.Blocks[0].Transaction); got != 1 {
+		t.Fatalf("len(bc.Blocks[0].Transaction) = %d, want 1 (the double-spend should have been rejected)", got)
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import "math/big"
+
+// genesisBits is the compact-encoded target used for the first block and
+// as the easiest (maximum) target difficulty is ever relaxed back to. This
+// is deliberately far easier than a mainnet Bitcoin target (which assumes a
+// large distributed hashrate) so that FindValidNonce's single-threaded
+// search finds a block in a handful of hashes instead of minutes.
+const genesisBits uint32 = 0x207fffff
+
+// retargetInterval is how many blocks pass between difficulty adjustments.
+const retargetInterval = 10
+
+// targetBlockTimeSecs is the number of seconds a block is expected to take
+// at the current difficulty; retargeting scales the target by how actual
+// elapsed time over retargetInterval blocks compares to this.
+const targetBlockTimeSecs int64 = 10
+
+// CompactToBig expands a compact ("nBits") representation into the full
+// target it encodes: the low 3 bytes are the mantissa and the high byte is
+// a base-256 exponent, following Bitcoin's nBits format.
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var n *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		n = big.NewInt(int64(mantissa))
+	} else {
+		n = big.NewInt(int64(mantissa))
+		n.Lsh(n, 8*(exponent-3))
+	}
+
+	if isNegative {
+		n.Neg(n)
+	}
+	return n
+}
+
+// BigToCompact compresses a target back into its nBits representation.
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Set(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+
+	// The high bit of the mantissa byte doubles as a sign flag, so a
+	// mantissa that would set it needs to shift into the next exponent.
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+	return compact
+}
+
+// HashToBig interprets a hex-encoded hash as a big-endian unsigned integer,
+// the form PoW target comparisons operate on.
+func HashToBig(hash string) *big.Int {
+	n := new(big.Int)
+	n.SetString(hash, 16)
+	return n
+}
+
+// nextDifficultyBits returns the compact target the next block must meet.
+// Every retargetInterval blocks it is rescaled by how the actual elapsed
+// time over that window compares to retargetInterval*targetBlockTimeSecs,
+// à la Bitcoin's 2016-block retarget, clamped to a 4x adjustment per window
+// so difficulty can't swing wildly from a handful of fast or slow blocks.
+func (bc *Blockchain) nextDifficultyBits() uint32 {
+	if len(bc.Blocks) == 0 {
+		return genesisBits
+	}
+
+	last := bc.Blocks[len(bc.Blocks)-1]
+	if len(bc.Blocks)%retargetInterval != 0 {
+		return last.Difficulty
+	}
+
+	first := bc.Blocks[len(bc.Blocks)-retargetInterval]
+	actualTimespan := last.Timestamp - first.Timestamp
+	expectedTimespan := retargetInterval * targetBlockTimeSecs
+
+	if actualTimespan < expectedTimespan/4 {
+		actualTimespan = expectedTimespan / 4
+	}
+	if actualTimespan > expectedTimespan*4 {
+		actualTimespan = expectedTimespan * 4
+	}
+
+	newTarget := new(big.Int).Mul(CompactToBig(last.Difficulty), big.NewInt(actualTimespan))
+	newTarget.Div(newTarget, big.NewInt(expectedTimespan))
+
+	if maxTarget := CompactToBig(genesisBits); newTarget.Cmp(maxTarget) > 0 {
+		newTarget = maxTarget
+	}
+	return BigToCompact(newTarget)
+}
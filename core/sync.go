@@ -0,0 +1,73 @@
+package core
+
+// AcceptBlock is how a p2p node hands Blockchain a block it received from a
+// peer. It validates the block on its own merits, then either appends it
+// (if it extends the current tip) or stashes it as a side chain; if that
+// side chain is now longer than the main chain, Blockchain reorgs onto it,
+// the same longest-chain rule Bitcoin uses to resolve forks.
+func (bc *Blockchain) AcceptBlock(block Block) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if err := bc.validateBlock(block, len(bc.Blocks)); err != nil {
+		return err
+	}
+
+	if bc.sideBlocks == nil {
+		bc.sideBlocks = make(map[string]Block)
+	}
+
+	tip := bc.recentBlock()
+	if block.PreviousHash == tip.CurrentHash {
+		return bc.appendBlock(block)
+	}
+
+	bc.sideBlocks[block.CurrentHash] = block
+	if candidate := bc.sideChainFrom(block); len(candidate) > len(bc.Blocks) {
+		return bc.reorgTo(candidate)
+	}
+	return nil
+}
+
+// sideChainFrom walks backward from tip through sideBlocks until it meets a
+// block already on the main chain (or reaches the genesis point), returning
+// the full candidate chain in height order, or nil if the chain can't yet
+// be traced back that far (e.g. an intermediate block hasn't arrived).
+// Callers must hold bc.mu.
+func (bc *Blockchain) sideChainFrom(tip Block) []Block {
+	var suffix []Block
+	current := tip
+	for {
+		suffix = append([]Block{current}, suffix...)
+
+		if current.PreviousHash == "" {
+			return suffix
+		}
+		if height, ok := bc.hashIndex[current.PreviousHash]; ok {
+			prefix := append([]Block{}, bc.Blocks[:height+1]...)
+			return append(prefix, suffix...)
+		}
+		parent, ok := bc.sideBlocks[current.PreviousHash]
+		if !ok {
+			return nil
+		}
+		current = parent
+	}
+}
+
+// reorgTo replaces the main chain with chain, rebuilding the UTXO set and
+// indexes from scratch and re-persisting every block. The blocks in chain
+// are assumed to have already passed validateBlock. Callers must hold bc.mu.
+func (bc *Blockchain) reorgTo(chain []Block) error {
+	bc.Blocks = nil
+	bc.HeaderHashList = nil
+	bc.hashIndex = nil
+	bc.UTXOSet = NewUTXOSet()
+
+	for _, block := range chain {
+		if err := bc.appendBlock(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
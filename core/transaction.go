@@ -0,0 +1,114 @@
+package core
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// TxInput references the output being spent: the hash of the transaction
+// that created it and its index within that transaction's Outputs.
+type TxInput struct {
+	TxHash      string
+	OutputIndex int
+}
+
+// TxOutput pays Amount to whoever holds the private key behind PubKeyHash.
+type TxOutput struct {
+	PubKeyHash string
+	Amount     int64
+}
+
+// Transaction moves value from the outputs referenced by Inputs to new
+// Outputs. A Transaction with no Inputs mints new value, the same way a
+// coinbase transaction does, and is accepted by UTXOSet.VerifyTransaction
+// without a signature or balance check, provided every output amount is
+// positive. Every other transaction must carry a valid
+// ECDSA (P-256) Signature from Sender over its canonical serialization.
+type Transaction struct {
+	Inputs    []TxInput
+	Outputs   []TxOutput
+	Sender    []byte // uncompressed P-256 public key of the signer
+	Signature []byte // ASN.1 DER-encoded (r, s)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// signingBytes serializes everything the signature covers -- inputs,
+// outputs and the sender's public key -- but not the signature itself.
+func (tx *Transaction) signingBytes() []byte {
+	var buf bytes.Buffer
+	for _, in := range tx.Inputs {
+		buf.WriteString(in.TxHash)
+		binary.Write(&buf, binary.BigEndian, int64(in.OutputIndex))
+	}
+	for _, out := range tx.Outputs {
+		buf.WriteString(out.PubKeyHash)
+		binary.Write(&buf, binary.BigEndian, out.Amount)
+	}
+	buf.Write(tx.Sender)
+	return buf.Bytes()
+}
+
+// Bytes serializes the full transaction, signature included. It is used as
+// the Merkle leaf for this transaction and in the block header hash.
+func (tx *Transaction) Bytes() []byte {
+	return append(tx.signingBytes(), tx.Signature...)
+}
+
+// Hash returns the double-sha256 hash of the transaction, used to identify
+// it on-chain and to reference its outputs from later TxInputs.
+func (tx *Transaction) Hash() string {
+	first := sha256.Sum256(tx.signingBytes())
+	second := sha256.Sum256(first[:])
+	return fmt.Sprintf("%x", second)
+}
+
+// Sign signs the transaction with priv, setting Sender and Signature.
+func (tx *Transaction) Sign(priv *ecdsa.PrivateKey) error {
+	tx.Sender = elliptic.Marshal(priv.PublicKey.Curve, priv.PublicKey.X, priv.PublicKey.Y)
+
+	digest := sha256.Sum256(tx.signingBytes())
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	if err != nil {
+		return err
+	}
+	sig, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+	if err != nil {
+		return err
+	}
+	tx.Signature = sig
+	return nil
+}
+
+// VerifySignature checks that Signature is a valid P-256 ECDSA signature by
+// Sender over the transaction's canonical serialization. It does not check
+// balances or double-spends; that requires the UTXO set, so it's
+// UTXOSet.VerifyTransaction's job.
+func (tx *Transaction) VerifySignature() bool {
+	if len(tx.Sender) == 0 || len(tx.Signature) == 0 {
+		return false
+	}
+
+	x, y := elliptic.Unmarshal(elliptic.P256(), tx.Sender)
+	if x == nil {
+		return false
+	}
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	var sig ecdsaSignature
+	if _, err := asn1.Unmarshal(tx.Signature, &sig); err != nil {
+		return false
+	}
+
+	digest := sha256.Sum256(tx.signingBytes())
+	return ecdsa.Verify(pub, digest[:], sig.R, sig.S)
+}
@@ -0,0 +1,96 @@
+package core
+
+import "fmt"
+
+// UTXO identifies one unspent output: the hash of the transaction that
+// created it and its index within that transaction's Outputs.
+type UTXO struct {
+	TxHash      string
+	OutputIndex int
+}
+
+// UTXOSet tracks every currently-unspent transaction output. Blockchain
+// consults it to reject double-spends and unbalanced transactions before a
+// transaction is allowed into a block.
+type UTXOSet struct {
+	outputs map[UTXO]TxOutput
+}
+
+// NewUTXOSet creates an empty UTXOSet.
+func NewUTXOSet() *UTXOSet {
+	return &UTXOSet{outputs: make(map[UTXO]TxOutput)}
+}
+
+// VerifyTransaction checks that tx is eligible for inclusion in a block:
+// every output amount must be positive; beyond that, a transaction with no
+// inputs mints new value (coinbase-style) and is accepted; otherwise every
+// input must reference an unspent output owned by
+// Sender, inputs must not be spent twice within tx, the signature must
+// verify, and outputs must not exceed inputs.
+func (set *UTXOSet) VerifyTransaction(tx *Transaction) error {
+	for _, out := range tx.Outputs {
+		if out.Amount <= 0 {
+			return fmt.Errorf("transaction %s: output amount %d is not positive", tx.Hash(), out.Amount)
+		}
+	}
+
+	if len(tx.Inputs) == 0 {
+		return nil
+	}
+
+	if !tx.VerifySignature() {
+		return fmt.Errorf("transaction %s: invalid signature", tx.Hash())
+	}
+
+	seen := make(map[UTXO]bool, len(tx.Inputs))
+	var inputTotal int64
+	for _, in := range tx.Inputs {
+		key := UTXO{TxHash: in.TxHash, OutputIndex: in.OutputIndex}
+		if seen[key] {
+			return fmt.Errorf("transaction %s: input %v spent twice in the same transaction", tx.Hash(), key)
+		}
+		seen[key] = true
+
+		out, ok := set.outputs[key]
+		if !ok {
+			return fmt.Errorf("transaction %s: input %v is not an unspent output", tx.Hash(), key)
+		}
+		if PubKeyHash(tx.Sender) != out.PubKeyHash {
+			return fmt.Errorf("transaction %s: input %v does not belong to sender", tx.Hash(), key)
+		}
+		inputTotal += out.Amount
+	}
+
+	var outputTotal int64
+	for _, out := range tx.Outputs {
+		outputTotal += out.Amount
+	}
+	if outputTotal > inputTotal {
+		return fmt.Errorf("transaction %s: outputs (%d) exceed inputs (%d)", tx.Hash(), outputTotal, inputTotal)
+	}
+	return nil
+}
+
+// Clone returns a copy of set that can be mutated independently, so a batch
+// of candidate transactions can be spent against it speculatively (e.g. to
+// catch transactions within the same batch that double-spend each other)
+// without touching the committed set until the batch is accepted.
+func (set *UTXOSet) Clone() *UTXOSet {
+	clone := &UTXOSet{outputs: make(map[UTXO]TxOutput, len(set.outputs))}
+	for k, v := range set.outputs {
+		clone.outputs[k] = v
+	}
+	return clone
+}
+
+// Apply spends tx's inputs and records its outputs as unspent, assuming
+// VerifyTransaction has already accepted tx.
+func (set *UTXOSet) Apply(tx *Transaction) {
+	for _, in := range tx.Inputs {
+		delete(set.outputs, UTXO{TxHash: in.TxHash, OutputIndex: in.OutputIndex})
+	}
+	hash := tx.Hash()
+	for i, out := range tx.Outputs {
+		set.outputs[UTXO{TxHash: hash, OutputIndex: i}] = out
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import "testing"
+
+// TestVerifyTransaction_RejectsNonPositiveOutput guards against an output
+// amount being zero or negative, which could otherwise offset an
+// over-sized output elsewhere in the same transaction and defeat the
+// outputs-must-not-exceed-inputs balance check.
+func TestVerifyTransaction_RejectsNonPositiveOutput(t *testing.T) {
+	set := NewUTXOSet()
+
+	wallet, err := NewWallet()
+	if err != nil {
+		t.Fatalf("NewWallet() error: %v", err)
+	}
+	funding := Transaction{Outputs: []TxOutput{{PubKeyHash: wallet.Address(), Amount: 100}}}
+	set.Apply(&funding)
+
+	spend := Transaction{
+		Inputs: []TxInput{{TxHash: funding.Hash(), OutputIndex: 0}},
+		Outputs: []TxOutput{
+			{PubKeyHash: "attacker", Amount: 1000000},
+			{PubKeyHash: wallet.Address(), Amount: -999900},
+		},
+	}
+	if err := wallet.SignTransaction(&spend); err != nil {
+		t.Fatalf("SignTransaction() error: %v", err)
+	}
+
+	if err := set.VerifyTransaction(&spend); err == nil {
+		t.Fatal("VerifyTransaction() accepted a transaction with a negative output amount")
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Wallet holds an ECDSA (P-256) keypair and signs transactions on behalf of
+// its owner.
+type Wallet struct {
+	PrivateKey *ecdsa.PrivateKey
+	PublicKey  []byte // uncompressed point; matches Transaction.Sender
+}
+
+// NewWallet generates a fresh P-256 keypair.
+func NewWallet() (*Wallet, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	pub := elliptic.Marshal(elliptic.P256(), priv.PublicKey.X, priv.PublicKey.Y)
+	return &Wallet{PrivateKey: priv, PublicKey: pub}, nil
+}
+
+// Address returns the hex-encoded hash of the wallet's public key, the
+// value transaction outputs pay to.
+func (w *Wallet) Address() string {
+	return PubKeyHash(w.PublicKey)
+}
+
+// PubKeyHash hashes an uncompressed public key into the value stored in
+// TxOutput.PubKeyHash.
+func PubKeyHash(pub []byte) string {
+	h := sha256.Sum256(pub)
+	return fmt.Sprintf("%x", h)
+}
+
+// SignTransaction signs tx with the wallet's private key.
+func (w *Wallet) SignTransaction(tx *Transaction) error {
+	return tx.Sign(w.PrivateKey)
+}
@@ -0,0 +1,141 @@
+// Package merkle implements a Bitcoin-style Merkle tree: leaves are hashed
+// as sha256(sha256(data)), an odd node at any level is duplicated rather
+// than padded with an empty leaf, and internal nodes hash the raw
+// concatenation of their children's digests. It supports building
+// SPV-style inclusion proofs and verifying them against a root without
+// access to the full tree.
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+// ErrNotFound is returned by Path when the requested leaf hash is not part
+// of the tree.
+var ErrNotFound = errors.New("merkle: leaf not found")
+
+// ProofNode is one step of an inclusion proof: the sibling hash to combine
+// with the running hash, and which side of the pair it sits on.
+type ProofNode struct {
+	Hash    [32]byte
+	IsRight bool // true if Hash is the right-hand sibling of the running hash
+}
+
+// MerkleTree is a binary hash tree built over a fixed set of leaves.
+type MerkleTree struct {
+	levels [][][32]byte // levels[0] is the leaves, levels[len-1] is {root}
+}
+
+// HashLeaf hashes raw leaf data as sha256(sha256(data)), matching Bitcoin's
+// transaction-hash convention.
+func HashLeaf(data []byte) [32]byte {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
+
+func hashPair(left, right [32]byte) [32]byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, left[:]...)
+	buf = append(buf, right[:]...)
+	first := sha256.Sum256(buf)
+	return sha256.Sum256(first[:])
+}
+
+// New builds a MerkleTree over raw leaf data (e.g. serialized
+// transactions), hashing each leaf with HashLeaf first.
+func New(leaves [][]byte) *MerkleTree {
+	hashes := make([][32]byte, len(leaves))
+	for i, l := range leaves {
+		hashes[i] = HashLeaf(l)
+	}
+	return NewFromLeafHashes(hashes)
+}
+
+// NewFromLeafHashes builds a MerkleTree directly from already-hashed
+// leaves, for callers (such as Block) that persist leaf hashes instead of
+// re-hashing the raw transactions on every lookup.
+func NewFromLeafHashes(leafHashes [][32]byte) *MerkleTree {
+	if len(leafHashes) == 0 {
+		return &MerkleTree{levels: [][][32]byte{{}}}
+	}
+
+	level := append([][32]byte{}, leafHashes...)
+	levels := [][][32]byte{level}
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		next := make([][32]byte, len(level)/2)
+		for i := range next {
+			next[i] = hashPair(level[2*i], level[2*i+1])
+		}
+		levels = append(levels, next)
+		level = next
+	}
+	return &MerkleTree{levels: levels}
+}
+
+// Root returns the Merkle root of the tree.
+func (t *MerkleTree) Root() [32]byte {
+	top := t.levels[len(t.levels)-1]
+	if len(top) == 0 {
+		return [32]byte{}
+	}
+	return top[0]
+}
+
+// Leaves returns the tree's leaf hashes, in order.
+func (t *MerkleTree) Leaves() [][32]byte {
+	return t.levels[0]
+}
+
+// Path returns the inclusion proof for the leaf whose hash is leafHash,
+// walking from that leaf up to the root. The same leaf hash appearing more
+// than once among the leaves (e.g. two identical transactions) resolves to
+// its first occurrence.
+func (t *MerkleTree) Path(leafHash [32]byte) ([]ProofNode, error) {
+	idx := -1
+	for i, h := range t.levels[0] {
+		if h == leafHash {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil, ErrNotFound
+	}
+
+	var proof []ProofNode
+	for _, level := range t.levels[:len(t.levels)-1] {
+		lvl := level
+		if len(lvl)%2 == 1 {
+			lvl = append(append([][32]byte{}, lvl...), lvl[len(lvl)-1])
+		}
+
+		isRightNode := idx%2 == 1
+		sibIdx := idx + 1
+		if isRightNode {
+			sibIdx = idx - 1
+		}
+		proof = append(proof, ProofNode{Hash: lvl[sibIdx], IsRight: !isRightNode})
+		idx /= 2
+	}
+	return proof, nil
+}
+
+// VerifyProof recomputes the root from leafHash and proof and reports
+// whether it matches root. It needs no access to the tree itself, which is
+// what makes it usable by SPV clients that only hold block headers.
+func VerifyProof(root [32]byte, leafHash [32]byte, proof []ProofNode) bool {
+	current := leafHash
+	for _, node := range proof {
+		if node.IsRight {
+			current = hashPair(current, node.Hash)
+		} else {
+			current = hashPair(node.Hash, current)
+		}
+	}
+	return bytes.Equal(current[:], root[:])
+}
@@ -0,0 +1,29 @@
+package p2p
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// LoadSeeds reads one peer address ("host:port") per line from path, the
+// config file format Node.Start's seeds argument is meant to come from.
+// Blank lines and lines starting with # are ignored.
+func LoadSeeds(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var seeds []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		seeds = append(seeds, line)
+	}
+	return seeds, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/core"
+)
+
+// Mempool holds transactions a peer has gossiped that aren't yet in a
+// block, keyed by transaction hash, until a miner packages them into one.
+type Mempool struct {
+	mu  sync.Mutex
+	txs map[string]core.Transaction
+}
+
+// NewMempool creates an empty Mempool.
+func NewMempool() *Mempool {
+	return &Mempool{txs: make(map[string]core.Transaction)}
+}
+
+// Add records tx in the mempool, keyed by its hash.
+func (m *Mempool) Add(tx core.Transaction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.txs[tx.Hash()] = tx
+}
+
+// Remove drops the transaction with the given hash, e.g. once it has been
+// mined into a block.
+func (m *Mempool) Remove(hash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.txs, hash)
+}
+
+// Take returns every transaction currently in the mempool.
+func (m *Mempool) Take() []core.Transaction {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	txs := make([]core.Transaction, 0, len(m.txs))
+	for _, tx := range m.txs {
+		txs = append(txs, tx)
+	}
+	return txs
+}
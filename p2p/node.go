@@ -0,0 +1,238 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/core"
+)
+
+const protocolVersion = 1
+
+// Node is a P2P participant: it serves incoming connections, dials seed
+// peers, performs a version/verack handshake with each, and gossips blocks
+// and transactions to keep its Blockchain in sync with the network.
+type Node struct {
+	bc         *core.Blockchain
+	Mempool    *Mempool
+	listenAddr string
+
+	mu    sync.Mutex
+	peers map[string]net.Conn
+}
+
+// NewNode creates a Node backed by bc and registers itself as bc's
+// Broadcaster, so bc.NewBlock gossips every block it mines.
+func NewNode(bc *core.Blockchain) *Node {
+	n := &Node{
+		bc:      bc,
+		Mempool: NewMempool(),
+		peers:   make(map[string]net.Conn),
+	}
+	bc.Broadcaster = n
+	return n
+}
+
+// Start listens on listenAddr for inbound peers and dials every address in
+// seeds, handshaking with each as they connect.
+func (n *Node) Start(listenAddr string, seeds []string) error {
+	n.listenAddr = listenAddr
+
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return fmt.Errorf("p2p: listen on %s: %w", listenAddr, err)
+	}
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				log.Println("p2p: accept error:", err)
+				return
+			}
+			go n.handleConn(conn, false)
+		}
+	}()
+
+	for _, seed := range seeds {
+		go n.dial(seed)
+	}
+	return nil
+}
+
+func (n *Node) dial(addr string) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		log.Println("p2p: dial", addr, "failed:", err)
+		return
+	}
+	n.handleConn(conn, true)
+}
+
+// handleConn runs the version/verack handshake over conn and then services
+// inv/getdata/block/tx messages from that peer until it disconnects.
+func (n *Node) handleConn(conn net.Conn, initiator bool) {
+	defer conn.Close()
+	peerAddr := conn.RemoteAddr().String()
+
+	if initiator {
+		version := VersionPayload{ProtocolVersion: protocolVersion, BestHeight: n.bc.Height(), ListenAddr: n.listenAddr}
+		if err := writeMessage(conn, MsgVersion, version); err != nil {
+			log.Println("p2p: send version to", peerAddr, "failed:", err)
+			return
+		}
+	}
+
+	handshakeDone := false
+	for {
+		msgType, payload, err := readMessage(conn)
+		if err != nil {
+			log.Println("p2p: connection to", peerAddr, "closed:", err)
+			n.removePeer(peerAddr)
+			return
+		}
+
+		switch msgType {
+		case MsgVersion:
+			if !initiator {
+				version := VersionPayload{ProtocolVersion: protocolVersion, BestHeight: n.bc.Height(), ListenAddr: n.listenAddr}
+				if err := writeMessage(conn, MsgVersion, version); err != nil {
+					log.Println("p2p: send version to", peerAddr, "failed:", err)
+					return
+				}
+			}
+			if err := writeMessage(conn, MsgVerack, nil); err != nil {
+				log.Println("p2p: send verack to", peerAddr, "failed:", err)
+				return
+			}
+			n.addPeer(peerAddr, conn)
+			handshakeDone = true
+
+		case MsgVerack:
+			n.addPeer(peerAddr, conn)
+			handshakeDone = true
+
+		case MsgInv:
+			if !handshakeDone {
+				continue
+			}
+			var inv InvPayload
+			if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&inv); err != nil {
+				log.Println("p2p: decode inv from", peerAddr, "failed:", err)
+				continue
+			}
+			var want []string
+			for _, hash := range inv.BlockHashes {
+				if _, ok := n.bc.GetBlockByHash(hash); !ok {
+					want = append(want, hash)
+				}
+			}
+			if len(want) > 0 {
+				if err := writeMessage(conn, MsgGetData, GetDataPayload{BlockHashes: want}); err != nil {
+					log.Println("p2p: send getdata to", peerAddr, "failed:", err)
+				}
+			}
+
+		case MsgGetData:
+			var req GetDataPayload
+			if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&req); err != nil {
+				log.Println("p2p: decode getdata from", peerAddr, "failed:", err)
+				continue
+			}
+			for _, hash := range req.BlockHashes {
+				if block, ok := n.bc.GetBlockByHash(hash); ok {
+					if err := writeMessage(conn, MsgBlock, block); err != nil {
+						log.Println("p2p: send block to", peerAddr, "failed:", err)
+					}
+				}
+			}
+
+		case MsgBlock:
+			var block core.Block
+			if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&block); err != nil {
+				log.Println("p2p: decode block from", peerAddr, "failed:", err)
+				continue
+			}
+			if err := n.bc.AcceptBlock(block); err != nil {
+				log.Println("p2p: rejected block from", peerAddr, ":", err)
+			}
+
+		case MsgTx:
+			var tx core.Transaction
+			if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&tx); err != nil {
+				log.Println("p2p: decode tx from", peerAddr, "failed:", err)
+				continue
+			}
+			n.Mempool.Add(tx)
+		}
+	}
+}
+
+func (n *Node) addPeer(addr string, conn net.Conn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peers[addr] = conn
+}
+
+func (n *Node) removePeer(addr string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.peers, addr)
+}
+
+// BroadcastBlock announces block to every connected peer via an inv
+// message, satisfying core.Broadcaster.
+func (n *Node) BroadcastBlock(block core.Block) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for addr, conn := range n.peers {
+		if err := writeMessage(conn, MsgInv, InvPayload{BlockHashes: []string{block.CurrentHash}}); err != nil {
+			log.Println("p2p: broadcast block to", addr, "failed:", err)
+		}
+	}
+}
+
+// BroadcastTransaction announces tx to every connected peer.
+func (n *Node) BroadcastTransaction(tx core.Transaction) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for addr, conn := range n.peers {
+		if err := writeMessage(conn, MsgTx, tx); err != nil {
+			log.Println("p2p: broadcast tx to", addr, "failed:", err)
+		}
+	}
+}
+
+// MineLoop periodically drains the Mempool into bc.NewBlock, so
+// transactions gossiped in over MsgTx (or submitted via sendrawtransaction)
+// are eventually mined instead of sitting in the mempool forever. It blocks
+// until stop is closed (a nil stop runs forever), so callers run it in its
+// own goroutine.
+func (n *Node) MineLoop(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			txs := n.Mempool.Take()
+			if len(txs) == 0 {
+				continue
+			}
+			heightBefore := n.bc.Height()
+			n.bc.NewBlock(txs)
+			if n.bc.Height() > heightBefore {
+				for _, tx := range txs {
+					n.Mempool.Remove(tx.Hash())
+				}
+			}
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/core"
+)
+
+// TransactionPool accepts transactions for later inclusion in a block, e.g.
+// a p2p.Mempool. It's declared here rather than imported so rpc doesn't
+// need to depend on the p2p package to be useful on its own.
+type TransactionPool interface {
+	Add(tx core.Transaction)
+}
+
+// Request is a JSON-RPC 2.0 request object.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// RPCError is a JSON-RPC 2.0 error object.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Response is a JSON-RPC 2.0 response object.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// Server exposes a core.Blockchain over JSON-RPC 2.0, implementing
+// getblockcount, getblockbyheight, getblockbyhash, getrawtransaction,
+// sendrawtransaction, getmerkleproof and verifychain.
+type Server struct {
+	bc   *core.Blockchain
+	Pool TransactionPool // optional; used by sendrawtransaction if set
+}
+
+// NewServer creates a Server backed by bc. pool may be nil, in which case
+// sendrawtransaction only decodes and hashes the transaction.
+func NewServer(bc *core.Blockchain, pool TransactionPool) *Server {
+	return &Server{bc: bc, Pool: pool}
+}
+
+// ServeHTTP implements http.Handler, decoding a JSON-RPC 2.0 request body
+// and dispatching it to the matching method.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, Response{JSONRPC: "2.0", Error: &RPCError{Code: -32700, Message: "parse error: " + err.Error()}})
+		return
+	}
+
+	result, err := s.dispatch(req.Method, req.Params)
+	resp := Response{JSONRPC: "2.0", ID: req.ID}
+	if err != nil {
+		resp.Error = &RPCError{Code: -32000, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	writeResponse(w, resp)
+}
+
+func writeResponse(w http.ResponseWriter, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "getblockcount":
+		return s.bc.Height(), nil
+
+	case "getblockbyheight":
+		var p struct {
+			Height int `json:"height"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.getBlockByHeight(p.Height)
+
+	case "getblockbyhash":
+		var p struct {
+			Hash string `json:"hash"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.getBlockByHash(p.Hash)
+
+	case "getrawtransaction":
+		var p struct {
+			BlockHeight int    `json:"blockHeight"`
+			Hash        string `json:"hash"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.getRawTransaction(p.BlockHeight, p.Hash)
+
+	case "sendrawtransaction":
+		var p struct {
+			Transaction Transaction `json:"transaction"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.sendRawTransaction(p.Transaction)
+
+	case "getmerkleproof":
+		var p struct {
+			BlockHeight int `json:"blockHeight"`
+			TxIndex     int `json:"txIndex"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("invalid params: %w", err)
+		}
+		return s.getMerkleProof(p.BlockHeight, p.TxIndex)
+
+	case "verifychain":
+		return s.bc.VerifyChain(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
+
+func (s *Server) getBlockByHeight(height int) (Block, error) {
+	block, ok := s.bc.BlockAt(height)
+	if !ok {
+		return Block{}, fmt.Errorf("block height %d out of range", height)
+	}
+	return newBlock(block), nil
+}
+
+func (s *Server) getBlockByHash(hash string) (Block, error) {
+	block, ok := s.bc.GetBlockByHash(hash)
+	if !ok {
+		return Block{}, fmt.Errorf("no block with hash %s", hash)
+	}
+	return newBlock(block), nil
+}
+
+// getRawTransaction finds the transaction with hash within the block at
+// blockHeight. core has no global transaction index, so the caller is
+// expected to already know (or look up via inv/getdata) which block it's
+// in.
+func (s *Server) getRawTransaction(blockHeight int, hash string) (Transaction, error) {
+	block, ok := s.bc.BlockAt(blockHeight)
+	if !ok {
+		return Transaction{}, fmt.Errorf("block height %d out of range", blockHeight)
+	}
+	for _, tx := range block.Transaction {
+		if tx.Hash() == hash {
+			return newTransaction(tx), nil
+		}
+	}
+	return Transaction{}, fmt.Errorf("no transaction %s in block %d", hash, blockHeight)
+}
+
+// sendRawTransaction decodes tx and, if a TransactionPool is configured,
+// hands it over for inclusion in a future block.
+func (s *Server) sendRawTransaction(tx Transaction) (string, error) {
+	coreTx, err := tx.toCore()
+	if err != nil {
+		return "", fmt.Errorf("invalid transaction: %w", err)
+	}
+	if s.Pool != nil {
+		s.Pool.Add(coreTx)
+	}
+	return coreTx.Hash(), nil
+}
+
+func (s *Server) getMerkleProof(blockHeight, txIndex int) (MerkleProof, error) {
+	root, proof, err := s.bc.GetMerkleProof(blockHeight, txIndex)
+	if err != nil {
+		return MerkleProof{}, err
+	}
+	nodes := make([]ProofNode, len(proof))
+	for i, node := range proof {
+		nodes[i] = ProofNode{Hash: hex.EncodeToString(node.Hash[:]), IsRight: node.IsRight}
+	}
+	return MerkleProof{Root: root, Proof: nodes}, nil
+}
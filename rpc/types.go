@@ -0,0 +1,118 @@
+// Package rpc exposes a core.Blockchain over JSON-RPC 2.0, giving external
+// tools and light clients a stable integration point instead of the
+// interactive CLI in main.go.
+package rpc
+
+import (
+	"encoding/base64"
+
+	"github.com/Hania-Khan/Merkle-Enabled-Blockchain-Data-Integrity-System/core"
+)
+
+// TxInput is the JSON-serializable form of core.TxInput.
+type TxInput struct {
+	TxHash      string `json:"txHash"`
+	OutputIndex int    `json:"outputIndex"`
+}
+
+// TxOutput is the JSON-serializable form of core.TxOutput.
+type TxOutput struct {
+	PubKeyHash string `json:"pubKeyHash"`
+	Amount     int64  `json:"amount"`
+}
+
+// Transaction is the JSON-serializable form of core.Transaction: Sender and
+// Signature, raw bytes in core, become base64 strings; Hash is hex, as
+// computed by core.Transaction.Hash.
+type Transaction struct {
+	Hash      string     `json:"hash"`
+	Inputs    []TxInput  `json:"inputs"`
+	Outputs   []TxOutput `json:"outputs"`
+	Sender    string     `json:"sender"`
+	Signature string     `json:"signature"`
+}
+
+func newTransaction(tx core.Transaction) Transaction {
+	inputs := make([]TxInput, len(tx.Inputs))
+	for i, in := range tx.Inputs {
+		inputs[i] = TxInput{TxHash: in.TxHash, OutputIndex: in.OutputIndex}
+	}
+	outputs := make([]TxOutput, len(tx.Outputs))
+	for i, out := range tx.Outputs {
+		outputs[i] = TxOutput{PubKeyHash: out.PubKeyHash, Amount: out.Amount}
+	}
+	return Transaction{
+		Hash:      tx.Hash(),
+		Inputs:    inputs,
+		Outputs:   outputs,
+		Sender:    base64.StdEncoding.EncodeToString(tx.Sender),
+		Signature: base64.StdEncoding.EncodeToString(tx.Signature),
+	}
+}
+
+// toCore converts t back into a core.Transaction, for sendrawtransaction.
+func (t Transaction) toCore() (core.Transaction, error) {
+	sender, err := base64.StdEncoding.DecodeString(t.Sender)
+	if err != nil {
+		return core.Transaction{}, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(t.Signature)
+	if err != nil {
+		return core.Transaction{}, err
+	}
+
+	inputs := make([]core.TxInput, len(t.Inputs))
+	for i, in := range t.Inputs {
+		inputs[i] = core.TxInput{TxHash: in.TxHash, OutputIndex: in.OutputIndex}
+	}
+	outputs := make([]core.TxOutput, len(t.Outputs))
+	for i, out := range t.Outputs {
+		outputs[i] = core.TxOutput{PubKeyHash: out.PubKeyHash, Amount: out.Amount}
+	}
+
+	return core.Transaction{
+		Inputs:    inputs,
+		Outputs:   outputs,
+		Sender:    sender,
+		Signature: signature,
+	}, nil
+}
+
+// Block is the JSON-serializable form of core.Block.
+type Block struct {
+	Transactions []Transaction `json:"transactions"`
+	Nonce        int           `json:"nonce"`
+	PreviousHash string        `json:"previousHash"`
+	CurrentHash  string        `json:"currentHash"`
+	MerkleRoot   string        `json:"merkleRoot"`
+	Timestamp    int64         `json:"timestamp"`
+	Difficulty   uint32        `json:"difficulty"`
+}
+
+func newBlock(block core.Block) Block {
+	txs := make([]Transaction, len(block.Transaction))
+	for i, tx := range block.Transaction {
+		txs[i] = newTransaction(tx)
+	}
+	return Block{
+		Transactions: txs,
+		Nonce:        block.Nonce,
+		PreviousHash: block.PreviousHash,
+		CurrentHash:  block.CurrentHash,
+		MerkleRoot:   block.MerkleRoot,
+		Timestamp:    block.Timestamp,
+		Difficulty:   block.Difficulty,
+	}
+}
+
+// ProofNode is the JSON-serializable form of merkle.ProofNode: Hash is hex.
+type ProofNode struct {
+	Hash    string `json:"hash"`
+	IsRight bool   `json:"isRight"`
+}
+
+// MerkleProof is the result of a getmerkleproof call.
+type MerkleProof struct {
+	Root  string      `json:"root"`
+	Proof []ProofNode `json:"proof"`
+}
@@ -0,0 +1,57 @@
+package storage
+
+import "sync"
+
+// MemCachedStore wraps a persistent Store with an in-memory write-through
+// cache, so repeated reads of hot keys (the chain tip, recent headers) don't
+// round-trip to disk. Unlike neo-go's MemCachedStore this one writes through
+// immediately rather than batching a Persist(), since the blockchain only
+// ever appends and never needs to roll a batch back.
+type MemCachedStore struct {
+	Store
+
+	mu       sync.RWMutex
+	memCache map[string][]byte
+}
+
+// NewMemCachedStore wraps lower with an in-memory read/write cache.
+func NewMemCachedStore(lower Store) *MemCachedStore {
+	return &MemCachedStore{
+		Store:    lower,
+		memCache: make(map[string][]byte),
+	}
+}
+
+func (s *MemCachedStore) Put(key, value []byte) error {
+	s.mu.Lock()
+	s.memCache[string(key)] = append([]byte(nil), value...)
+	s.mu.Unlock()
+	return s.Store.Put(key, value)
+}
+
+func (s *MemCachedStore) Get(key []byte) ([]byte, error) {
+	s.mu.RLock()
+	v, ok := s.memCache[string(key)]
+	s.mu.RUnlock()
+	if ok {
+		return append([]byte(nil), v...), nil
+	}
+	return s.Store.Get(key)
+}
+
+func (s *MemCachedStore) Has(key []byte) (bool, error) {
+	s.mu.RLock()
+	_, ok := s.memCache[string(key)]
+	s.mu.RUnlock()
+	if ok {
+		return true, nil
+	}
+	return s.Store.Has(key)
+}
+
+func (s *MemCachedStore) Delete(key []byte) error {
+	s.mu.Lock()
+	delete(s.memCache, string(key))
+	s.mu.Unlock()
+	return s.Store.Delete(key)
+}
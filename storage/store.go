@@ -0,0 +1,24 @@
+// Package storage provides the persistence layer for the blockchain. It
+// defines the key/value Store interface every backend implements and the
+// backends themselves (MemoryStore, LevelDBStore, MemCachedStore), mirroring
+// the storage layer split used by neo-go.
+package storage
+
+import "errors"
+
+// ErrKeyNotFound is returned by Get when the requested key does not exist.
+var ErrKeyNotFound = errors.New("storage: key not found")
+
+// Store is the minimal key/value interface a persistence backend must
+// implement. Callers are expected to namespace their keys so that blocks
+// are addressable both by height and by hash (see the key helpers in
+// blockchain_store.go).
+type Store interface {
+	Put(key, value []byte) error
+	Get(key []byte) ([]byte, error)
+	Has(key []byte) (bool, error)
+	Delete(key []byte) error
+	// Seek invokes f for every key/value pair whose key starts with prefix.
+	Seek(prefix []byte, f func(key, value []byte))
+	Close() error
+}